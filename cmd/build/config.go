@@ -0,0 +1,20 @@
+package main
+
+import "github.com/BurntSushi/toml"
+
+// cacheConfig mirrors params.cache in hugo.toml.
+type cacheConfig struct {
+	Params struct {
+		Cache struct {
+			MaxMB int `toml:"maxMB"`
+		} `toml:"cache"`
+	} `toml:"params"`
+}
+
+func loadCacheMaxMB(path string) (int, error) {
+	var cfg cacheConfig
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return 0, err
+	}
+	return cfg.Params.Cache.MaxMB, nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadCacheMaxMB(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hugo.toml")
+	content := "[params.cache]\n  maxMB = 256\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	got, err := loadCacheMaxMB(path)
+	if err != nil {
+		t.Fatalf("loadCacheMaxMB: %v", err)
+	}
+	if got != 256 {
+		t.Errorf("loadCacheMaxMB = %d, want 256", got)
+	}
+}
+
+func TestLoadCacheMaxMBUnset(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hugo.toml")
+	if err := os.WriteFile(path, []byte(`title = "blog-cocl2"`), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	got, err := loadCacheMaxMB(path)
+	if err != nil {
+		t.Fatalf("loadCacheMaxMB: %v", err)
+	}
+	if got != 0 {
+		t.Errorf("loadCacheMaxMB = %d, want 0 (unset)", got)
+	}
+}
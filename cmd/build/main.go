@@ -0,0 +1,198 @@
+// Command build wraps `hugo_extended` with the steps that must run before
+// it: syntax-theme CSS generation (cmd/gen-chromastyles) and OG-image
+// generation (cmd/ogimage). This wrapper tracks, via internal/buildcache's
+// manifest, whether a step's declared dependencies changed at all since the
+// last run, so an unchanged tree skips invoking the generator entirely
+// rather than re-walking content/ just to find nothing to do. Each step's
+// generated artifacts are also loaded into the memory-bounded cache (see
+// internal/buildcache), so the SLRU eviction policy is exercised against
+// real PNG/CSS bytes rather than placeholder markers.
+//
+// Usage mirrors hugo itself; any flags after -- are passed through:
+//
+//	build -- --gc --minify
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"blog-cocl2/internal/buildcache"
+)
+
+const (
+	manifestPath = "resources/_gen/cache-manifest.json"
+	metricsPath  = "resources/_gen/cache-metrics.json"
+	contentDir   = "content"
+)
+
+// baseDependencyFiles are hashed for every step to decide whether its
+// declared dependency set has changed: site params (hugo.toml) and module
+// versions (go.mod).
+var baseDependencyFiles = []string{"hugo.toml", "go.mod"}
+
+// steps are the generators this wrapper runs before hugo itself, in order.
+// outDir is where each step writes its artifacts, which get loaded into the
+// memory-bounded cache after a run so eviction has real bytes to work with.
+// depsOnContent marks steps whose output depends on content/, which isn't
+// named in baseDependencyFiles since it isn't a single file.
+var steps = []struct {
+	name          string
+	cmd           []string
+	outDir        string
+	depsOnContent bool
+}{
+	{"syntax-theme", []string{"gen-chromastyles"}, "assets/css/chroma", false},
+	{"og-image", []string{"ogimage"}, "resources/_gen/og", true},
+}
+
+func main() {
+	configPath := flag.String("config", "hugo.toml", "path to the Hugo site config")
+	maxMB := flag.Int("cache-max-mb", 0, "cache memory ceiling in MB (0 = params.cache.maxMB, or 25% of total system memory if that's also unset)")
+	flag.Parse()
+	hugoArgs := flag.Args()
+
+	if *maxMB == 0 {
+		configMaxMB, err := loadCacheMaxMB(*configPath)
+		if err != nil {
+			log.Fatalf("build: loading %s: %v", *configPath, err)
+		}
+		*maxMB = configMaxMB
+	}
+
+	cache := buildcache.New(buildcache.WithMaxMB(*maxMB))
+	manifest, err := buildcache.LoadManifest(manifestPath)
+	if err != nil {
+		log.Fatalf("build: loading %s: %v", manifestPath, err)
+	}
+
+	baseHash, err := hashFiles(baseDependencyFiles)
+	if err != nil {
+		log.Fatalf("build: hashing dependencies: %v", err)
+	}
+	contentHash, err := hashDir(contentDir)
+	if err != nil {
+		log.Fatalf("build: hashing %s: %v", contentDir, err)
+	}
+
+	for _, step := range steps {
+		depHash := baseHash
+		if step.depsOnContent {
+			depHash = baseHash + contentHash
+		}
+		if err := runCached(cache, manifest, step.name, step.cmd, step.outDir, depHash); err != nil {
+			log.Fatalf("build: %s: %v", step.name, err)
+		}
+	}
+
+	if err := buildcache.SaveManifest(manifestPath, manifest); err != nil {
+		log.Printf("build: writing %s: %v", manifestPath, err)
+	}
+	if err := buildcache.WriteMetrics(metricsPath, cache.Metrics()); err != nil {
+		log.Printf("build: writing %s: %v", metricsPath, err)
+	}
+
+	hugo := exec.Command("hugo_extended", hugoArgs...)
+	hugo.Stdout, hugo.Stderr, hugo.Stdin = os.Stdout, os.Stderr, os.Stdin
+	if err := hugo.Run(); err != nil {
+		log.Fatalf("build: hugo_extended: %v", err)
+	}
+}
+
+// runCached invokes cmdline unless the manifest already records this step's
+// current dependency hash, meaning nothing it depends on has changed since
+// the last run. Either way, outDir's current artifacts are loaded into
+// cache so the memory-bounded policy is bounding real generated bytes
+// rather than a placeholder marker.
+func runCached(cache *buildcache.Cache, manifest map[string]buildcache.ManifestEntry, name string, cmdline []string, outDir, depHash string) error {
+	if entry, ok := manifest[name]; ok && entry.Hash == depHash {
+		fmt.Printf("build: %s: unchanged, skipping\n", name)
+		return cacheArtifacts(cache, name, outDir, depHash)
+	}
+
+	cmd := exec.Command(cmdline[0], cmdline[1:]...)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	manifest[name] = buildcache.ManifestEntry{Hash: depHash, Path: outDir}
+	return cacheArtifacts(cache, name, outDir, depHash)
+}
+
+// cacheArtifacts reads every file under outDir into cache, keyed by this
+// step's dependency hash plus the file's path relative to outDir, so a
+// changed dependency hash naturally addresses a disjoint set of entries
+// instead of colliding with a stale generation's bytes.
+func cacheArtifacts(cache *buildcache.Cache, name, outDir, depHash string) error {
+	return filepath.WalkDir(outDir, func(path string, d fs.DirEntry, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(outDir, path)
+		if err != nil {
+			rel = path
+		}
+		cache.Put(buildcache.Key{Step: name, Deps: []string{depHash, rel}}, data)
+		return nil
+	})
+}
+
+func hashFiles(paths []string) (string, error) {
+	h := sha256.New()
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashDir folds every file under dir into a single hash, keyed by its path
+// plus size and mtime rather than its full contents: content/ can hold a
+// lot of post text and images, and a cheap per-file stat is enough to
+// detect an add, edit, or delete without rereading the whole tree on every
+// build.
+func hashDir(dir string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\x00", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHashDirChangesOnNewFile(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "post-one.md"), "hello")
+
+	before, err := hashDir(dir)
+	if err != nil {
+		t.Fatalf("hashDir: %v", err)
+	}
+
+	mustWrite(t, filepath.Join(dir, "post-two.md"), "world")
+
+	after, err := hashDir(dir)
+	if err != nil {
+		t.Fatalf("hashDir: %v", err)
+	}
+
+	if before == after {
+		t.Error("hashDir should change when a new file is added under the directory")
+	}
+}
+
+func TestHashDirChangesOnEditedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "post.md")
+	mustWrite(t, path, "v1")
+
+	before, err := hashDir(dir)
+	if err != nil {
+		t.Fatalf("hashDir: %v", err)
+	}
+
+	// hashDir keys off size/mtime rather than content, so advance both to
+	// simulate a real edit.
+	if err := os.WriteFile(path, []byte("v2 is longer"), 0o644); err != nil {
+		t.Fatalf("rewriting fixture: %v", err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	after, err := hashDir(dir)
+	if err != nil {
+		t.Fatalf("hashDir: %v", err)
+	}
+
+	if before == after {
+		t.Error("hashDir should change when an existing file is edited")
+	}
+}
+
+func TestHashDirStableWhenUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "post.md"), "hello")
+
+	a, err := hashDir(dir)
+	if err != nil {
+		t.Fatalf("hashDir: %v", err)
+	}
+	b, err := hashDir(dir)
+	if err != nil {
+		t.Fatalf("hashDir: %v", err)
+	}
+	if a != b {
+		t.Error("hashDir should be stable across calls when nothing changed")
+	}
+}
+
+func TestHashFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.toml")
+	b := filepath.Join(dir, "b.mod")
+	mustWrite(t, a, "hugo config")
+	mustWrite(t, b, "module blog-cocl2")
+
+	h1, err := hashFiles([]string{a, b})
+	if err != nil {
+		t.Fatalf("hashFiles: %v", err)
+	}
+
+	mustWrite(t, a, "changed hugo config")
+	h2, err := hashFiles([]string{a, b})
+	if err != nil {
+		t.Fatalf("hashFiles: %v", err)
+	}
+
+	if h1 == h2 {
+		t.Error("hashFiles should change when a dependency file's content changes")
+	}
+}
+
+func mustWrite(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+}
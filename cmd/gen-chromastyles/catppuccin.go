@@ -0,0 +1,87 @@
+package main
+
+import (
+	"github.com/alecthomas/chroma/v2"
+)
+
+// catppuccinPalette holds the subset of a Catppuccin flavor's named colors
+// that this package's style definitions reference. See
+// https://github.com/catppuccin/catppuccin for the full palette.
+type catppuccinPalette struct {
+	base, mantle, text, subtext0 string
+	rosewater, flamingo, pink    string
+	mauve, red, maroon           string
+	peach, yellow, green         string
+	teal, sky, sapphire          string
+	blue, lavender, overlay0     string
+}
+
+var (
+	catppuccinLatte = catppuccinPalette{
+		base: "#eff1f5", mantle: "#e6e9ef", text: "#4c4f69", subtext0: "#6c6f85",
+		rosewater: "#dc8a78", flamingo: "#dd7878", pink: "#ea76cb",
+		mauve: "#8839ef", red: "#d20f39", maroon: "#e64553",
+		peach: "#fe640b", yellow: "#df8e1d", green: "#40a02b",
+		teal: "#179299", sky: "#04a5e5", sapphire: "#209fb5",
+		blue: "#1e66f5", lavender: "#7287fd", overlay0: "#9ca0b0",
+	}
+	catppuccinFrappe = catppuccinPalette{
+		base: "#303446", mantle: "#292c3c", text: "#c6d0f5", subtext0: "#a5adce",
+		rosewater: "#f2d5cf", flamingo: "#eebebe", pink: "#f4b8e4",
+		mauve: "#ca9ee6", red: "#e78284", maroon: "#ea999c",
+		peach: "#ef9f76", yellow: "#e5c890", green: "#a6d189",
+		teal: "#81c8be", sky: "#99d1db", sapphire: "#85c1dc",
+		blue: "#8caaee", lavender: "#babbf1", overlay0: "#737994",
+	}
+	catppuccinMacchiato = catppuccinPalette{
+		base: "#24273a", mantle: "#1e2030", text: "#cad3f5", subtext0: "#a5adcb",
+		rosewater: "#f4dbd6", flamingo: "#f0c6c6", pink: "#f5bde6",
+		mauve: "#c6a0f6", red: "#ed8796", maroon: "#ee99a0",
+		peach: "#f5a97f", yellow: "#eed49f", green: "#a6da95",
+		teal: "#8bd5ca", sky: "#91d7e3", sapphire: "#7dc4e4",
+		blue: "#8aadf4", lavender: "#b7bdf8", overlay0: "#6e738d",
+	}
+	catppuccinMocha = catppuccinPalette{
+		base: "#1e1e2e", mantle: "#181825", text: "#cdd6f4", subtext0: "#a6adc8",
+		rosewater: "#f5e0dc", flamingo: "#f2cdcd", pink: "#f5c2e7",
+		mauve: "#cba6f7", red: "#f38ba8", maroon: "#eba0ac",
+		peach: "#fab387", yellow: "#f9e2af", green: "#a6e3a1",
+		teal: "#94e2d5", sky: "#89dceb", sapphire: "#74c7ec",
+		blue: "#89b4fa", lavender: "#b4befe", overlay0: "#6c7086",
+	}
+)
+
+// catppuccinStyle builds a Chroma style from a Catppuccin flavor, mapping
+// token types onto the palette the way the upstream Catppuccin ports for
+// other highlighters (VS Code, Alacritty, ...) do.
+func catppuccinStyle(name string, p catppuccinPalette) (*chroma.Style, error) {
+	return chroma.NewStyle(name, chroma.StyleEntries{
+		chroma.Background:          "bg:" + p.base + " " + p.text,
+		chroma.Text:                p.text,
+		chroma.Comment:             "italic " + p.overlay0,
+		chroma.CommentPreproc:      p.pink,
+		chroma.Keyword:             "bold " + p.mauve,
+		chroma.KeywordType:         p.lavender,
+		chroma.NameBuiltin:         p.blue,
+		chroma.NameFunction:        p.blue,
+		chroma.NameClass:           "bold " + p.yellow,
+		chroma.NameNamespace:       p.yellow,
+		chroma.NameException:       "bold " + p.red,
+		chroma.NameVariable:        p.rosewater,
+		chroma.NameConstant:        p.peach,
+		chroma.NameDecorator:       p.pink,
+		chroma.NameTag:             p.mauve,
+		chroma.NameAttribute:       p.yellow,
+		chroma.LiteralString:       p.green,
+		chroma.LiteralStringEscape: p.pink,
+		chroma.LiteralNumber:       p.peach,
+		chroma.Operator:            p.sky,
+		chroma.Punctuation:         p.overlay0,
+		chroma.GenericDeleted:      p.red,
+		chroma.GenericInserted:     p.green,
+		chroma.GenericEmph:         "italic",
+		chroma.GenericStrong:       "bold",
+		chroma.GenericHeading:      "bold " + p.blue,
+		chroma.Error:               "bg:" + p.mantle + " " + p.red,
+	})
+}
@@ -0,0 +1,85 @@
+// Command gen-chromastyles pre-generates the site's Chroma stylesheets at
+// build time, in place of shelling out to `hugo gen chromastyles` for each
+// theme by hand. It writes one CSS file per theme in themeRegistry plus an
+// "auto" stylesheet that gates the light/dark pair in autoPair behind
+// prefers-color-scheme, all under assets/css/chroma/ where
+// layouts/partials/head/syntax-theme.html picks them up via Hugo Pipes.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+)
+
+func main() {
+	outDir := flag.String("out", "assets/css/chroma", "destination directory for generated stylesheets")
+	flag.Parse()
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		log.Fatalf("gen-chromastyles: creating %s: %v", *outDir, err)
+	}
+
+	formatter := chromahtml.New(chromahtml.WithClasses(true))
+
+	byName := make(map[string]theme, len(themeRegistry))
+	for _, t := range themeRegistry {
+		byName[t.name] = t
+		if err := writeTheme(*outDir, formatter, t); err != nil {
+			log.Fatalf("gen-chromastyles: %s: %v", t.name, err)
+		}
+	}
+
+	if err := writeAuto(*outDir, formatter, byName[autoPair[0]], byName[autoPair[1]]); err != nil {
+		log.Fatalf("gen-chromastyles: auto: %v", err)
+	}
+}
+
+func writeTheme(outDir string, formatter *chromahtml.Formatter, t theme) error {
+	style, err := t.style()
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(outDir, t.name+".css"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return formatter.WriteCSS(f, style)
+}
+
+// writeAuto concatenates the light theme's CSS, then the dark theme's CSS
+// wrapped in a prefers-color-scheme: dark media query, so a single
+// stylesheet covers both without any JavaScript toggle.
+func writeAuto(outDir string, formatter *chromahtml.Formatter, light, dark theme) error {
+	lightStyle, err := light.style()
+	if err != nil {
+		return err
+	}
+	darkStyle, err := dark.style()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(outDir, "auto.css"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := formatter.WriteCSS(f, lightStyle); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, "@media (prefers-color-scheme: dark) {"); err != nil {
+		return err
+	}
+	if err := formatter.WriteCSS(f, darkStyle); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(f, "}")
+	return err
+}
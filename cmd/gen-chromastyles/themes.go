@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// theme pairs a site-facing name (as used in params.syntax.theme) with the
+// Chroma style it renders and whether it's a light or dark variant, so
+// "auto" mode can pick a sensible light/dark pair.
+type theme struct {
+	name  string
+	style func() (*chroma.Style, error)
+	dark  bool
+}
+
+// themeRegistry lists every theme this site ships a stylesheet for: the
+// four Catppuccin flavors plus a handful of other popular Chroma builtins
+// kept around for readers who prefer something else.
+var themeRegistry = []theme{
+	{name: "catppuccin-latte", style: func() (*chroma.Style, error) { return catppuccinStyle("catppuccin-latte", catppuccinLatte) }, dark: false},
+	{name: "catppuccin-frappe", style: func() (*chroma.Style, error) { return catppuccinStyle("catppuccin-frappe", catppuccinFrappe) }, dark: true},
+	{name: "catppuccin-macchiato", style: func() (*chroma.Style, error) { return catppuccinStyle("catppuccin-macchiato", catppuccinMacchiato) }, dark: true},
+	{name: "catppuccin-mocha", style: func() (*chroma.Style, error) { return catppuccinStyle("catppuccin-mocha", catppuccinMocha) }, dark: true},
+	{name: "github", style: lookupBuiltin("github"), dark: false},
+	{name: "dracula", style: lookupBuiltin("dracula"), dark: true},
+	{name: "monokai", style: lookupBuiltin("monokai"), dark: true},
+	{name: "solarized-light", style: lookupBuiltin("solarized-light"), dark: false},
+}
+
+// autoPair is the (light, dark) theme names emitted behind a
+// prefers-color-scheme media query when params.syntax.theme = "auto".
+var autoPair = [2]string{"catppuccin-latte", "catppuccin-mocha"}
+
+func lookupBuiltin(name string) func() (*chroma.Style, error) {
+	return func() (*chroma.Style, error) {
+		if s := styles.Get(name); s != nil {
+			return s, nil
+		}
+		return styles.Fallback, nil
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import "testing"
+
+func TestThemeRegistryStylesBuild(t *testing.T) {
+	for _, th := range themeRegistry {
+		t.Run(th.name, func(t *testing.T) {
+			style, err := th.style()
+			if err != nil {
+				t.Fatalf("style(): %v", err)
+			}
+			if style == nil {
+				t.Fatal("style() returned a nil *chroma.Style")
+			}
+		})
+	}
+}
+
+func TestThemeRegistryNamesAreUnique(t *testing.T) {
+	seen := make(map[string]bool, len(themeRegistry))
+	for _, th := range themeRegistry {
+		if seen[th.name] {
+			t.Errorf("duplicate theme name %q", th.name)
+		}
+		seen[th.name] = true
+	}
+}
+
+func TestAutoPairReferencesRegisteredThemes(t *testing.T) {
+	byName := make(map[string]theme, len(themeRegistry))
+	for _, th := range themeRegistry {
+		byName[th.name] = th
+	}
+
+	light, dark := autoPair[0], autoPair[1]
+	lt, ok := byName[light]
+	if !ok {
+		t.Fatalf("autoPair light theme %q not in themeRegistry", light)
+	}
+	if lt.dark {
+		t.Errorf("autoPair light theme %q is marked dark", light)
+	}
+
+	dt, ok := byName[dark]
+	if !ok {
+		t.Fatalf("autoPair dark theme %q not in themeRegistry", dark)
+	}
+	if !dt.dark {
+		t.Errorf("autoPair dark theme %q is not marked dark", dark)
+	}
+}
+
+func TestCatppuccinStyleBuilds(t *testing.T) {
+	flavors := map[string]catppuccinPalette{
+		"catppuccin-latte":     catppuccinLatte,
+		"catppuccin-frappe":    catppuccinFrappe,
+		"catppuccin-macchiato": catppuccinMacchiato,
+		"catppuccin-mocha":     catppuccinMocha,
+	}
+	for name, palette := range flavors {
+		t.Run(name, func(t *testing.T) {
+			style, err := catppuccinStyle(name, palette)
+			if err != nil {
+				t.Fatalf("catppuccinStyle: %v", err)
+			}
+			if style == nil {
+				t.Fatal("catppuccinStyle returned a nil style")
+			}
+		})
+	}
+}
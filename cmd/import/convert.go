@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// converter walks a parsed HTML document applying a rule set, falling back
+// to plain paragraph/inline handling and recording anything it doesn't
+// recognize into the source's unhandled-elements report.
+type converter struct {
+	rules     []rule
+	unhandled func(tag string)
+	imageSink imageSink
+	warn      func(format string, args ...any)
+}
+
+// imageSink lets --rewrite-image download and relocate images without the
+// rule set needing to know how the destination is reached.
+type imageSink interface {
+	rewrite(src string) (string, error)
+}
+
+func newConverter(rules []rule, unhandled func(tag string)) *converter {
+	return &converter{rules: rules, unhandled: unhandled}
+}
+
+// convert renders the <body> of doc to Markdown.
+func (c *converter) convert(doc *html.Node) string {
+	body := firstChildElement(doc, "body")
+	if body == nil {
+		body = doc
+	}
+	return c.convertChildren(body)
+}
+
+func (c *converter) convertChildren(n *html.Node) string {
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(c.convertNode(child))
+	}
+	return b.String()
+}
+
+func (c *converter) convertNode(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return n.Data
+	case html.ElementNode:
+		for _, r := range c.rules {
+			if md, ok := r.convert(c, n); ok {
+				return md
+			}
+		}
+		return c.convertDefault(n)
+	default:
+		return c.convertChildren(n)
+	}
+}
+
+// convertDefault handles the common elements that don't need a dedicated
+// rule: paragraphs, links, emphasis, lists, and images outside a <figure>.
+func (c *converter) convertDefault(n *html.Node) string {
+	switch n.Data {
+	case "p":
+		return c.inlineText(n) + "\n\n"
+	case "a":
+		return fmt.Sprintf("[%s](%s)", c.inlineText(n), attr(n, "href"))
+	case "strong", "b":
+		return fmt.Sprintf("**%s**", c.inlineText(n))
+	case "em", "i":
+		return fmt.Sprintf("*%s*", c.inlineText(n))
+	case "code":
+		return fmt.Sprintf("`%s`", c.rawText(n))
+	case "img":
+		return fmt.Sprintf("![%s](%s)", attr(n, "alt"), c.resolveImage(attr(n, "src")))
+	case "ul":
+		return c.convertList(n, "- ")
+	case "ol":
+		return c.convertList(n, "1. ")
+	case "br":
+		return "\n"
+	case "html", "head", "body", "div", "section", "article", "main", "span":
+		// Transparent containers: recurse without emitting anything of
+		// their own.
+		return c.convertChildren(n)
+	default:
+		if c.unhandled != nil {
+			c.unhandled(n.Data)
+		}
+		return c.convertChildren(n)
+	}
+}
+
+func (c *converter) convertList(n *html.Node, marker string) string {
+	var b strings.Builder
+	for _, li := range elements(n, "li") {
+		b.WriteString(marker + strings.TrimSpace(c.inlineText(li)) + "\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// inlineText renders n's children as inline Markdown (no trailing
+// paragraph break), trimming surrounding whitespace.
+func (c *converter) inlineText(n *html.Node) string {
+	return strings.TrimSpace(collapseWhitespace(c.convertChildren(n)))
+}
+
+// rawText returns n's text content verbatim, used inside <pre>/<code> where
+// whitespace is significant.
+func (c *converter) rawText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+func (c *converter) resolveImage(src string) string {
+	if c.imageSink == nil || src == "" {
+		return src
+	}
+	rewritten, err := c.imageSink.rewrite(src)
+	if err != nil {
+		if c.warn != nil {
+			c.warn("rewriting image %s: %v", src, err)
+		}
+		return src
+	}
+	return rewritten
+}
+
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func firstChildElement(n *html.Node, tag string) *html.Node {
+	found := elements(n, tag)
+	if len(found) == 0 {
+		return nil
+	}
+	return found[0]
+}
+
+// elements returns the descendants of n with the given tag name, depth-first.
+func elements(n *html.Node, tag string) []*html.Node {
+	return elementsAny(n, tag)
+}
+
+// elementsAny returns descendants of n matching any of the given tag names.
+func elementsAny(n *html.Node, tags ...string) []*html.Node {
+	var found []*html.Node
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.ElementNode {
+				for _, tag := range tags {
+					if child.Data == tag {
+						found = append(found, child)
+						break
+					}
+				}
+			}
+			walk(child)
+		}
+	}
+	walk(n)
+	return found
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func convertFragment(t *testing.T, body string) string {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader("<html><body>" + body + "</body></html>"))
+	if err != nil {
+		t.Fatalf("parsing fragment: %v", err)
+	}
+	return newConverter(defaultRules(), nil).convert(doc)
+}
+
+func TestConvertRules(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "heading levels",
+			body: "<h1>Title</h1><h3>Sub</h3>",
+			want: "# Title\n\n### Sub\n\n",
+		},
+		{
+			name: "code block with language class",
+			body: `<pre><code class="language-go">fmt.Println("hi")</code></pre>`,
+			want: "```go\nfmt.Println(\"hi\")\n```\n\n",
+		},
+		{
+			name: "code block without language",
+			body: "<pre><code>plain</code></pre>",
+			want: "```\nplain\n```\n\n",
+		},
+		{
+			name: "table",
+			body: "<table><tr><th>A</th><th>B</th></tr><tr><td>1</td><td>2</td></tr></table>",
+			want: "| A | B |\n| --- | --- |\n| 1 | 2 |\n\n",
+		},
+		{
+			name: "blockquote",
+			body: "<blockquote>quoted text</blockquote>",
+			want: "> quoted text\n\n",
+		},
+		{
+			name: "figure with caption",
+			body: `<figure><img src="cat.jpg" alt="A cat"><figcaption>A very good cat</figcaption></figure>`,
+			want: "![A cat](cat.jpg)\n*A very good cat*\n\n",
+		},
+		{
+			name: "figure without caption",
+			body: `<figure><img src="cat.jpg" alt="A cat"></figure>`,
+			want: "![A cat](cat.jpg)\n\n",
+		},
+		{
+			name: "paragraph and inline formatting",
+			body: "<p>Some <strong>bold</strong> and <em>italic</em> text.</p>",
+			want: "Some **bold** and *italic* text.\n\n",
+		},
+		{
+			name: "link",
+			body: `<p><a href="https://example.com">example</a></p>`,
+			want: "[example](https://example.com)\n\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := convertFragment(t, tc.body); got != tc.want {
+				t.Errorf("convert(%q) = %q, want %q", tc.body, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConvertRecordsUnhandledElements(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader("<html><body><aside>note</aside></body></html>"))
+	if err != nil {
+		t.Fatalf("parsing fragment: %v", err)
+	}
+
+	var unhandled []string
+	conv := newConverter(defaultRules(), func(tag string) { unhandled = append(unhandled, tag) })
+	conv.convert(doc)
+
+	if len(unhandled) != 1 || unhandled[0] != "aside" {
+		t.Fatalf("unhandled = %v, want [aside]", unhandled)
+	}
+}
+
+func TestResolveImageLogsRewriteFailures(t *testing.T) {
+	var warnings []string
+	conv := newConverter(nil, nil)
+	conv.imageSink = failingSink{}
+	conv.warn = func(format string, args ...any) {
+		warnings = append(warnings, format)
+	}
+
+	got := conv.resolveImage("broken.jpg")
+	if got != "broken.jpg" {
+		t.Errorf("resolveImage fallback = %q, want original src", got)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning logged, got %d: %v", len(warnings), warnings)
+	}
+}
+
+type failingSink struct{}
+
+func (failingSink) rewrite(src string) (string, error) {
+	return "", errFailingSink
+}
+
+var errFailingSink = &sinkError{"boom"}
+
+type sinkError struct{ msg string }
+
+func (e *sinkError) Error() string { return e.msg }
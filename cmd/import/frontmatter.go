@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// frontMatter is the Hugo front matter written at the top of each imported
+// post. Fields mirror content/posts' existing convention of lowercase TOML
+// keys with a YAML block; we use YAML here since it round-trips multi-line
+// values (titles with colons, etc.) more predictably than TOML.
+type frontMatter struct {
+	Title string
+	Date  time.Time
+	Slug  string
+	Draft bool
+}
+
+func (m frontMatter) writeTo(w io.Writer) error {
+	date := m.Date
+	if date.IsZero() {
+		date = time.Unix(0, 0).UTC()
+	}
+	_, err := fmt.Fprintf(w, "---\ntitle: %q\ndate: %s\nslug: %s\ndraft: %t\n---\n\n",
+		m.Title, date.Format(time.RFC3339), m.Slug, m.Draft)
+	return err
+}
+
+// extractFrontMatter pulls a title and publish date out of doc, preferring
+// the common meta tags legacy blogs use in this priority order:
+// article:published_time, then og:article:published_time, then a bare
+// <time datetime>, leaving the date zero (flagged via Draft) when nothing
+// is found. The title comes from <title>, then og:title, then <h1>.
+func extractFrontMatter(doc *html.Node, src source) frontMatter {
+	var m frontMatter
+	m.Title = findTitle(doc)
+
+	if t := metaContent(doc, "article:published_time"); t != "" {
+		m.Date = parseTime(t)
+	} else if t := metaContent(doc, "og:article:published_time"); t != "" {
+		m.Date = parseTime(t)
+	} else if t := timeDatetime(doc); t != "" {
+		m.Date = parseTime(t)
+	}
+
+	if m.Title == "" {
+		m.Title = src.String()
+	}
+	m.Draft = m.Date.IsZero()
+	return m
+}
+
+func parseTime(s string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func findTitle(doc *html.Node) string {
+	if n := firstChildElement(doc, "title"); n != nil {
+		if title := strings.TrimSpace(textContent(n)); title != "" {
+			return title
+		}
+	}
+	if og := metaContent(doc, "og:title"); og != "" {
+		return og
+	}
+	if h1 := firstChildElement(doc, "h1"); h1 != nil {
+		return strings.TrimSpace(textContent(h1))
+	}
+	return ""
+}
+
+// metaContent finds <meta property="key" content="..."> or
+// <meta name="key" content="...">, whichever is present.
+func metaContent(doc *html.Node, key string) string {
+	for _, meta := range elements(doc, "meta") {
+		if attr(meta, "property") == key || attr(meta, "name") == key {
+			return attr(meta, "content")
+		}
+	}
+	return ""
+}
+
+func timeDatetime(doc *html.Node) string {
+	if n := firstChildElement(doc, "time"); n != nil {
+		return attr(n, "datetime")
+	}
+	return ""
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return b.String()
+}
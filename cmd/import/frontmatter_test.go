@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestExtractFrontMatter(t *testing.T) {
+	cases := []struct {
+		name      string
+		doc       string
+		wantTitle string
+		wantDate  string // RFC3339, empty means zero/draft
+	}{
+		{
+			name:      "article:published_time takes priority",
+			doc:       `<html><head><title>T</title><meta property="article:published_time" content="2024-03-05T10:00:00Z"><meta name="og:title" content="Og Title"><time datetime="2020-01-01">x</time></head></html>`,
+			wantTitle: "T",
+			wantDate:  "2024-03-05T10:00:00Z",
+		},
+		{
+			name:      "falls back to og:article:published_time",
+			doc:       `<html><head><title>T</title><meta property="og:article:published_time" content="2023-01-02T00:00:00Z"></head></html>`,
+			wantTitle: "T",
+			wantDate:  "2023-01-02T00:00:00Z",
+		},
+		{
+			name:      "falls back to time datetime",
+			doc:       `<html><head><title>T</title></head><body><time datetime="2022-06-07">June 7</time></body></html>`,
+			wantTitle: "T",
+			wantDate:  "2022-06-07T00:00:00Z",
+		},
+		{
+			name:      "falls back to og:title when no <title>",
+			doc:       `<html><head><meta property="og:title" content="From OG"></head></html>`,
+			wantTitle: "From OG",
+		},
+		{
+			name:      "falls back to h1 when no title or og:title",
+			doc:       `<html><body><h1>  From H1  </h1></body></html>`,
+			wantTitle: "From H1",
+		},
+		{
+			name:      "no date found is draft",
+			doc:       `<html><head><title>T</title></head></html>`,
+			wantTitle: "T",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(tc.doc))
+			if err != nil {
+				t.Fatalf("parsing fixture: %v", err)
+			}
+			m := extractFrontMatter(doc, source{path: "test.html"})
+			if m.Title != tc.wantTitle {
+				t.Errorf("Title = %q, want %q", m.Title, tc.wantTitle)
+			}
+			if tc.wantDate == "" {
+				if !m.Date.IsZero() || !m.Draft {
+					t.Errorf("expected zero date and Draft=true, got Date=%v Draft=%v", m.Date, m.Draft)
+				}
+				return
+			}
+			if m.Draft {
+				t.Errorf("expected Draft=false when a date was found")
+			}
+			if got := m.Date.Format("2006-01-02T15:04:05Z07:00"); got != tc.wantDate {
+				t.Errorf("Date = %q, want %q", got, tc.wantDate)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// imageDownloader implements imageSink for --rewrite-image: it fetches each
+// referenced image once into destDir and rewrites the src to a site-root
+// path.
+//
+// destDir must be under static/ or assets/. Under static/, Hugo publishes
+// the tree verbatim, so stripping the "static/" prefix is the served path.
+// Under assets/, nothing is published until something in a template fetches
+// it through the pipeline (resources.Get), so the same site-root path only
+// resolves once layouts/_default/_markup/render-image.html's render hook
+// looks it up that way and serves its real (possibly fingerprinted)
+// Permalink instead — see that file for the other half of this.
+type imageDownloader struct {
+	destDir string
+	client  http.Client
+	seen    map[string]string // source URL/path -> rewritten site path
+}
+
+// sitePath turns destDir into the site-root path Hugo images downloaded
+// there are addressed by, stripping whichever of static/ or assets/ roots
+// it falls under. It errors for any other destDir since neither the direct
+// static/ publish path nor the render-image.html pipeline lookup would find
+// the file there.
+func sitePath(destDir string) (string, error) {
+	clean := filepath.ToSlash(filepath.Clean(destDir))
+	for _, root := range []string{"static", "assets"} {
+		if clean == root {
+			return "/", nil
+		}
+		if rel := strings.TrimPrefix(clean, root+"/"); rel != clean {
+			return "/" + rel, nil
+		}
+	}
+	return "", fmt.Errorf("rewrite-image destination %q must be under static/ or assets/", destDir)
+}
+
+func (d *imageDownloader) rewrite(src string) (string, error) {
+	if d.seen == nil {
+		d.seen = make(map[string]string)
+	}
+	if rewritten, ok := d.seen[src]; ok {
+		return rewritten, nil
+	}
+
+	dir, err := sitePath(d.destDir)
+	if err != nil {
+		return "", err
+	}
+
+	data, ext, err := d.fetch(src)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha1.Sum(data)
+	name := hex.EncodeToString(sum[:8]) + ext
+	if err := os.MkdirAll(d.destDir, 0o755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", d.destDir, err)
+	}
+	dest := filepath.Join(d.destDir, name)
+	if _, err := os.Stat(dest); err != nil {
+		if err := os.WriteFile(dest, data, 0o644); err != nil {
+			return "", fmt.Errorf("writing %s: %w", dest, err)
+		}
+	}
+
+	rewritten := path.Join(dir, name)
+	d.seen[src] = rewritten
+	return rewritten, nil
+}
+
+func (d *imageDownloader) fetch(src string) (data []byte, ext string, err error) {
+	u, err := url.Parse(src)
+	if err != nil || !u.IsAbs() {
+		data, err := os.ReadFile(src)
+		return data, filepath.Ext(src), err
+	}
+
+	if d.client.Timeout == 0 {
+		d.client.Timeout = 30 * time.Second
+	}
+	resp, err := d.client.Get(src)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching %s: %w", src, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("fetching %s: unexpected status %s", src, resp.Status)
+	}
+	data, err = io.ReadAll(resp.Body)
+	return data, filepath.Ext(u.Path), err
+}
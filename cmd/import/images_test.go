@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestSitePath(t *testing.T) {
+	cases := []struct {
+		destDir string
+		want    string
+		wantErr bool
+	}{
+		{"static", "/", false},
+		{"static/images", "/images", false},
+		{"static/images/posts", "/images/posts", false},
+		{"assets", "/", false},
+		{"assets/images", "/images", false},
+		{"content/posts", "", true},
+		{"other", "", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.destDir, func(t *testing.T) {
+			got, err := sitePath(tc.destDir)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("sitePath(%q) = %q, want error", tc.destDir, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sitePath(%q) unexpected error: %v", tc.destDir, err)
+			}
+			if got != tc.want {
+				t.Errorf("sitePath(%q) = %q, want %q", tc.destDir, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/net/html"
+)
+
+// importer converts sources into Markdown files under outDir.
+type importer struct {
+	outDir       string
+	rewriteImage string // dir under static/ or assets/ to download images into, empty disables rewriting
+}
+
+// importOne converts a single source to a Markdown file with front matter
+// and writes it under i.outDir. Unhandled elements are recorded on report
+// keyed by the source.
+func (i *importer) importOne(src source, report *report) error {
+	rc, err := src.open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	doc, err := html.Parse(rc)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", src, err)
+	}
+
+	meta := extractFrontMatter(doc, src)
+
+	conv := newConverter(defaultRules(), report.forSource(src.String()))
+	conv.warn = func(format string, args ...any) {
+		log.Printf("import: %s: "+format, append([]any{src.String()}, args...)...)
+	}
+	if i.rewriteImage != "" {
+		conv.imageSink = &imageDownloader{destDir: i.rewriteImage}
+	}
+	body := conv.convert(doc)
+
+	slug := stableSlug(meta.Title, src.String())
+	meta.Slug = slug
+
+	if err := os.MkdirAll(i.outDir, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", i.outDir, err)
+	}
+	dest := filepath.Join(i.outDir, slug+".md")
+
+	// Idempotent: re-importing the same source overwrites the same file
+	// rather than accumulating duplicates, since the slug is derived solely
+	// from the title (falling back to the source path/URL).
+	f, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", dest, err)
+	}
+	defer f.Close()
+
+	if err := meta.writeTo(f); err != nil {
+		return fmt.Errorf("writing front matter for %s: %w", dest, err)
+	}
+	if _, err := f.WriteString(body); err != nil {
+		return fmt.Errorf("writing body for %s: %w", dest, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+// Command import migrates legacy HTML posts into Hugo-compatible Markdown
+// content under content/posts/.
+//
+// It accepts a directory of local .html files, one or more URLs to crawl, or
+// both. Each input is converted through a pipeline of pluggable rules (see
+// rules.go), given a stable slug (see slug.go), and written out with a YAML
+// front matter block. A per-run report of elements the rules didn't know how
+// to handle is printed at the end so the rule set can be iterated on.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+const contentDir = "content/posts"
+
+func main() {
+	var (
+		dir          = flag.String("dir", "", "directory of legacy .html files to import")
+		urls         multiFlag
+		rewriteImage = flag.String("rewrite-image", "", "download referenced images into this dir, under static/ or assets/ (e.g. static/images), and rewrite src attributes")
+		outDir       = flag.String("out", contentDir, "destination directory for generated Markdown")
+		reportPath   = flag.String("report", "", "path to write the unhandled-elements report (defaults to stderr)")
+	)
+	flag.Var(&urls, "url", "URL to crawl and import (may be repeated)")
+	flag.Parse()
+
+	if *dir == "" && len(urls) == 0 {
+		fmt.Fprintln(os.Stderr, "import: at least one of -dir or -url is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	imp := &importer{
+		outDir:       *outDir,
+		rewriteImage: *rewriteImage,
+	}
+
+	var sources []source
+	if *dir != "" {
+		found, err := collectHTMLFiles(*dir)
+		if err != nil {
+			log.Fatalf("import: collecting files under %s: %v", *dir, err)
+		}
+		sources = append(sources, found...)
+	}
+	for _, u := range urls {
+		sources = append(sources, source{url: u})
+	}
+
+	report := newReport()
+	for _, src := range sources {
+		if err := imp.importOne(src, report); err != nil {
+			log.Printf("import: %s: %v", src, err)
+		}
+	}
+
+	w := os.Stderr
+	if *reportPath != "" {
+		f, err := os.Create(*reportPath)
+		if err != nil {
+			log.Fatalf("import: opening report file: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	report.writeTo(w)
+}
+
+// collectHTMLFiles walks dir and returns a source for every .html or .htm
+// file found.
+func collectHTMLFiles(dir string) ([]source, error) {
+	var out []source
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if filepath.Ext(path) != ".html" && filepath.Ext(path) != ".htm" {
+			return nil
+		}
+		out = append(out, source{path: path})
+		return nil
+	})
+	return out, err
+}
+
+// multiFlag collects repeated -url flags into a slice.
+type multiFlag []string
+
+func (m *multiFlag) String() string {
+	return fmt.Sprint([]string(*m))
+}
+
+func (m *multiFlag) Set(v string) error {
+	*m = append(*m, v)
+	return nil
+}
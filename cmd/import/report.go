@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// report tallies, per source, which HTML elements none of the rules knew
+// how to handle, so the operator can see at a glance where to add or tune a
+// rule.
+type report struct {
+	unhandled map[string]map[string]int // source -> tag -> count
+}
+
+func newReport() *report {
+	return &report{unhandled: make(map[string]map[string]int)}
+}
+
+// forSource returns a callback suitable for converter.unhandled that
+// records hits against the given source.
+func (r *report) forSource(src string) func(tag string) {
+	return func(tag string) {
+		if r.unhandled[src] == nil {
+			r.unhandled[src] = make(map[string]int)
+		}
+		r.unhandled[src][tag]++
+	}
+}
+
+func (r *report) writeTo(w io.Writer) {
+	if len(r.unhandled) == 0 {
+		fmt.Fprintln(w, "import: no unhandled elements")
+		return
+	}
+
+	sources := make([]string, 0, len(r.unhandled))
+	for src := range r.unhandled {
+		sources = append(sources, src)
+	}
+	sort.Strings(sources)
+
+	for _, src := range sources {
+		tags := r.unhandled[src]
+		if len(tags) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s:\n", src)
+		names := make([]string, 0, len(tags))
+		for tag := range tags {
+			names = append(names, tag)
+		}
+		sort.Strings(names)
+		for _, tag := range names {
+			fmt.Fprintf(w, "  <%s>: %d\n", tag, tags[tag])
+		}
+	}
+}
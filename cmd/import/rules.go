@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// rule converts a single HTML node into Markdown. It returns ok=false when
+// the node isn't one it knows how to handle, letting the converter fall
+// through to the next rule (or record it as unhandled).
+type rule interface {
+	convert(c *converter, n *html.Node) (md string, ok bool)
+}
+
+// defaultRules returns the built-in rule set, tried in order: headings,
+// code blocks, tables, blockquotes, then figures with captions.
+func defaultRules() []rule {
+	return []rule{
+		headingRule{},
+		codeBlockRule{},
+		tableRule{},
+		blockquoteRule{},
+		figureRule{},
+	}
+}
+
+type headingRule struct{}
+
+func (headingRule) convert(c *converter, n *html.Node) (string, bool) {
+	level, ok := headingLevel(n.Data)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s\n\n", strings.Repeat("#", level), c.inlineText(n)), true
+}
+
+func headingLevel(tag string) (int, bool) {
+	switch tag {
+	case "h1":
+		return 1, true
+	case "h2":
+		return 2, true
+	case "h3":
+		return 3, true
+	case "h4":
+		return 4, true
+	case "h5":
+		return 5, true
+	case "h6":
+		return 6, true
+	default:
+		return 0, false
+	}
+}
+
+type codeBlockRule struct{}
+
+func (codeBlockRule) convert(c *converter, n *html.Node) (string, bool) {
+	if n.Data != "pre" {
+		return "", false
+	}
+	lang := ""
+	if code := firstChildElement(n, "code"); code != nil {
+		lang = codeLanguage(code)
+	}
+	return fmt.Sprintf("```%s\n%s\n```\n\n", lang, strings.TrimRight(c.rawText(n), "\n")), true
+}
+
+// codeLanguage recovers a Chroma/highlight.js language hint from a
+// `language-xxx` or `lang-xxx` class on the <code> element, if present.
+func codeLanguage(code *html.Node) string {
+	for _, attr := range code.Attr {
+		if attr.Key != "class" {
+			continue
+		}
+		for _, class := range strings.Fields(attr.Val) {
+			if lang, ok := strings.CutPrefix(class, "language-"); ok {
+				return lang
+			}
+			if lang, ok := strings.CutPrefix(class, "lang-"); ok {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+type tableRule struct{}
+
+func (tableRule) convert(c *converter, n *html.Node) (string, bool) {
+	if n.Data != "table" {
+		return "", false
+	}
+	var rows [][]string
+	for _, row := range elements(n, "tr") {
+		var cells []string
+		for _, cell := range elementsAny(row, "th", "td") {
+			cells = append(cells, c.inlineText(cell))
+		}
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 {
+		return "", true
+	}
+
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	writeRow(rows[0])
+	sep := make([]string, len(rows[0]))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	writeRow(sep)
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	b.WriteString("\n")
+	return b.String(), true
+}
+
+type blockquoteRule struct{}
+
+func (blockquoteRule) convert(c *converter, n *html.Node) (string, bool) {
+	if n.Data != "blockquote" {
+		return "", false
+	}
+	inner := strings.TrimSpace(c.convertChildren(n))
+	var b strings.Builder
+	for _, line := range strings.Split(inner, "\n") {
+		b.WriteString("> " + line + "\n")
+	}
+	b.WriteString("\n")
+	return b.String(), true
+}
+
+// figureRule converts <figure><img><figcaption> into a Markdown image
+// followed by an italicized caption line, matching how the rest of this
+// site's posts caption inline images.
+type figureRule struct{}
+
+func (figureRule) convert(c *converter, n *html.Node) (string, bool) {
+	if n.Data != "figure" {
+		return "", false
+	}
+	img := firstChildElement(n, "img")
+	if img == nil {
+		return "", false
+	}
+	alt, src := attr(img, "alt"), c.resolveImage(attr(img, "src"))
+	md := fmt.Sprintf("![%s](%s)", alt, src)
+	if cap := firstChildElement(n, "figcaption"); cap != nil {
+		md += fmt.Sprintf("\n*%s*", c.inlineText(cap))
+	}
+	return md + "\n\n", true
+}
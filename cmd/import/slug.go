@@ -0,0 +1,30 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+var slugInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+// stableSlug derives a URL-safe, idempotent slug from the post's title,
+// falling back to a short hash of the source identifier when the title is
+// empty or reduces to nothing usable. Re-running the importer against the
+// same source always produces the same slug, which is what makes the
+// importer idempotent: it overwrites rather than duplicates.
+func stableSlug(title, fallback string) string {
+	slug := slugify(title)
+	if slug != "" {
+		return slug
+	}
+	sum := sha1.Sum([]byte(fallback))
+	return "post-" + hex.EncodeToString(sum[:])[:8]
+}
+
+func slugify(s string) string {
+	s = strings.ToLower(s)
+	s = slugInvalid.ReplaceAllString(s, "-")
+	return strings.Trim(s, "-")
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestStableSlug(t *testing.T) {
+	cases := []struct {
+		name     string
+		title    string
+		fallback string
+		want     string
+	}{
+		{"simple title", "Hello World", "ignored", "hello-world"},
+		{"punctuation collapses to dashes", "Hello, World!! (2024)", "ignored", "hello-world-2024"},
+		{"leading/trailing punctuation trimmed", "--Hello--", "ignored", "hello"},
+		{"empty title falls back to hash", "", "https://example.com/post", "post-"},
+		{"title reducing to nothing falls back to hash", "!!!", "https://example.com/post", "post-"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := stableSlug(tc.title, tc.fallback)
+			if tc.title == "" || tc.want == "post-" {
+				if len(got) != len("post-")+8 || got[:5] != "post-" {
+					t.Fatalf("stableSlug(%q, %q) = %q, want fallback hash form", tc.title, tc.fallback, got)
+				}
+				return
+			}
+			if got != tc.want {
+				t.Errorf("stableSlug(%q, %q) = %q, want %q", tc.title, tc.fallback, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStableSlugIdempotent(t *testing.T) {
+	a := stableSlug("My Post Title", "https://example.com/a")
+	b := stableSlug("My Post Title", "https://example.com/a")
+	if a != b {
+		t.Fatalf("stableSlug is not idempotent: %q != %q", a, b)
+	}
+}
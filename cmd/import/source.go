@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// source is a single legacy document to import, either a local file or a
+// remote URL. Exactly one of path or url is set.
+type source struct {
+	path string
+	url  string
+}
+
+func (s source) String() string {
+	if s.path != "" {
+		return s.path
+	}
+	return s.url
+}
+
+// open returns the raw HTML for the source. No publish time comes with it;
+// that is parsed from the document itself, see frontmatter.go.
+func (s source) open() (io.ReadCloser, error) {
+	if s.path != "" {
+		return os.Open(s.path)
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(s.url)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", s.url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", s.url, resp.Status)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// manifestPath is where writeManifest persists the content-page ->
+// generated-image mapping that layouts/partials/og-image-meta.html reads.
+const manifestPath = cacheDir + "/manifest.json"
+
+func writeManifest(m map[string]string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(manifestPath, data, 0o644)
+}
+
+// pageInputs are the OG-image template inputs for a single page. Two pages
+// with identical inputs render to the same cached file, so a rename that
+// doesn't change any of these fields doesn't force a regeneration.
+type pageInputs struct {
+	Title, Author, Site, Accent, Cover string
+}
+
+// hash identifies the cache entry for these inputs. It folds in the cover
+// photo's mtime (when the cover is a local file) so editing the photo in
+// place busts the cache even though its path didn't change.
+func (p pageInputs) hash() string {
+	coverKey := p.Cover
+	if info, err := os.Stat(p.Cover); err == nil {
+		coverKey = fmt.Sprintf("%s@%d", p.Cover, info.ModTime().Unix())
+	}
+	sum := sha1.Sum([]byte(fmt.Sprintf("v1|%s|%s|%s|%s|%s", p.Title, p.Author, p.Site, p.Accent, coverKey)))
+	return hex.EncodeToString(sum[:])
+}
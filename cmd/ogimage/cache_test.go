@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPageInputsHashStable(t *testing.T) {
+	a := pageInputs{Title: "Hello", Author: "A", Site: "S", Accent: "#fff", Cover: "cover.jpg"}
+	b := pageInputs{Title: "Hello", Author: "A", Site: "S", Accent: "#fff", Cover: "cover.jpg"}
+	if a.hash() != b.hash() {
+		t.Fatal("identical inputs should hash identically")
+	}
+}
+
+func TestPageInputsHashDiffersPerField(t *testing.T) {
+	base := pageInputs{Title: "Hello", Author: "A", Site: "S", Accent: "#fff"}
+	h := base.hash()
+
+	variants := []pageInputs{
+		{Title: "Different", Author: "A", Site: "S", Accent: "#fff"},
+		{Title: "Hello", Author: "B", Site: "S", Accent: "#fff"},
+		{Title: "Hello", Author: "A", Site: "Other", Accent: "#fff"},
+		{Title: "Hello", Author: "A", Site: "S", Accent: "#000"},
+		{Title: "Hello", Author: "A", Site: "S", Accent: "#fff", Cover: "cover.jpg"},
+	}
+	for _, v := range variants {
+		if v.hash() == h {
+			t.Errorf("%+v hashed the same as base %+v", v, base)
+		}
+	}
+}
+
+func TestPageInputsHashBustsOnCoverEdit(t *testing.T) {
+	dir := t.TempDir()
+	cover := filepath.Join(dir, "cover.jpg")
+	if err := os.WriteFile(cover, []byte("v1"), 0o644); err != nil {
+		t.Fatalf("writing cover: %v", err)
+	}
+
+	inputs := pageInputs{Title: "T", Cover: cover}
+	before := inputs.hash()
+
+	// Bump mtime forward so the hash sees a different cover key even though
+	// the path is unchanged, matching an in-place photo edit.
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(cover, future, future); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	after := inputs.hash()
+	if before == after {
+		t.Error("editing the cover file in place should change the hash")
+	}
+}
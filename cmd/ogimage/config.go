@@ -0,0 +1,31 @@
+package main
+
+import "github.com/BurntSushi/toml"
+
+// siteConfig is the subset of hugo.toml ogimage needs as defaults for pages
+// that don't override them in their own front matter.
+type siteConfig struct {
+	Title  string `toml:"title"`
+	Params struct {
+		Author      string `toml:"author"`
+		AccentColor string `toml:"accentColor"`
+	} `toml:"params"`
+}
+
+type loadedSite struct {
+	Title       string
+	Author      string
+	AccentColor string
+}
+
+func loadSiteConfig(path string) (loadedSite, error) {
+	var cfg siteConfig
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return loadedSite{}, err
+	}
+	return loadedSite{
+		Title:       cfg.Title,
+		Author:      cfg.Params.Author,
+		AccentColor: cfg.Params.AccentColor,
+	}, nil
+}
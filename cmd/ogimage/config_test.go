@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSiteConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hugo.toml")
+	content := `
+title = "blog-cocl2"
+
+[params]
+  author = "Tenny Zhuang"
+  accentColor = "#1e66f5"
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	got, err := loadSiteConfig(path)
+	if err != nil {
+		t.Fatalf("loadSiteConfig: %v", err)
+	}
+	want := loadedSite{Title: "blog-cocl2", Author: "Tenny Zhuang", AccentColor: "#1e66f5"}
+	if got != want {
+		t.Errorf("loadSiteConfig = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadSiteConfigMissingFile(t *testing.T) {
+	if _, err := loadSiteConfig(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+		t.Fatal("expected an error for a missing config file")
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pageFrontMatter is the subset of a post's front matter this tool needs.
+// Front matter not listed here (date, slug, draft, ...) is ignored.
+type pageFrontMatter struct {
+	Title  string `yaml:"title"`
+	Author string `yaml:"author"`
+	Cover  string `yaml:"cover"`
+	Accent string `yaml:"accent"`
+}
+
+// parseFrontMatter reads the YAML front matter block (delimited by `---`
+// lines) from the top of a content file. Pages without a front matter
+// block return a zero pageFrontMatter, which the caller skips.
+func parseFrontMatter(path string) (pageFrontMatter, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return pageFrontMatter{}, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != "---" {
+		return pageFrontMatter{}, nil
+	}
+
+	var raw strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "---" {
+			break
+		}
+		raw.WriteString(line)
+		raw.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return pageFrontMatter{}, err
+	}
+
+	var fm pageFrontMatter
+	if err := yaml.Unmarshal([]byte(raw.String()), &fm); err != nil {
+		return pageFrontMatter{}, err
+	}
+	return fm, nil
+}
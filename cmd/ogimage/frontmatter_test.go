@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	dir := t.TempDir()
+
+	cases := []struct {
+		name    string
+		content string
+		want    pageFrontMatter
+	}{
+		{
+			name: "full front matter",
+			content: "---\n" +
+				"title: My Post\n" +
+				"author: Jane\n" +
+				"cover: cover.jpg\n" +
+				"accent: \"#1e66f5\"\n" +
+				"---\n\nbody text\n",
+			want: pageFrontMatter{Title: "My Post", Author: "Jane", Cover: "cover.jpg", Accent: "#1e66f5"},
+		},
+		{
+			name:    "no front matter block",
+			content: "just body text, no front matter\n",
+			want:    pageFrontMatter{},
+		},
+		{
+			name:    "front matter with only title",
+			content: "---\ntitle: Only Title\n---\nbody\n",
+			want:    pageFrontMatter{Title: "Only Title"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, tc.name+".md")
+			if err := os.WriteFile(path, []byte(tc.content), 0o644); err != nil {
+				t.Fatalf("writing fixture: %v", err)
+			}
+			got, err := parseFrontMatter(path)
+			if err != nil {
+				t.Fatalf("parseFrontMatter: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("parseFrontMatter = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Command ogimage pre-generates 1200x630 Open Graph / Twitter Card images
+// for every content page that doesn't already have one cached, so the site
+// build never shells out to an image service at request time.
+//
+// It's meant to run before `hugo`, e.g.:
+//
+//	ogimage && hugo --gc
+//
+// Generated PNGs are cached under resources/_gen/og/ keyed by a hash of the
+// template inputs (title, author, site name, accent color, cover photo),
+// so unchanged pages are skipped on subsequent builds.
+// The page -> image mapping is written to resources/_gen/og/manifest.json;
+// layouts/partials/og-image-meta.html looks a page up there, and
+// layouts/_internal/opengraph.html (which hugomods/seo/modules/open-graph
+// delegates to) emits the result as og:image.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	imageWidth  = 1200
+	imageHeight = 630
+	cacheDir    = "resources/_gen/og"
+)
+
+func main() {
+	contentDir := flag.String("content", "content", "content directory to scan for pages")
+	configPath := flag.String("config", "hugo.toml", "path to the Hugo site config")
+	flag.Parse()
+
+	site, err := loadSiteConfig(*configPath)
+	if err != nil {
+		log.Fatalf("ogimage: loading %s: %v", *configPath, err)
+	}
+
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		log.Fatalf("ogimage: creating %s: %v", cacheDir, err)
+	}
+
+	manifest := map[string]string{} // content page path -> cached PNG's site-relative URL
+
+	var generated, cached, skipped int
+	err = filepath.WalkDir(*contentDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		fm, err := parseFrontMatter(path)
+		if err != nil {
+			log.Printf("ogimage: %s: %v", path, err)
+			skipped++
+			return nil
+		}
+		if fm.Title == "" {
+			skipped++
+			return nil
+		}
+
+		inputs := pageInputs{
+			Title:  fm.Title,
+			Author: coalesce(fm.Author, site.Author),
+			Site:   site.Title,
+			Accent: coalesce(fm.Accent, site.AccentColor),
+			Cover:  resolveCover(fm.Cover, path),
+		}
+
+		name := inputs.hash() + ".png"
+		dest := filepath.Join(cacheDir, name)
+		manifest[contentRelPath(*contentDir, path)] = "/" + filepath.ToSlash(filepath.Join(cacheDir, name))
+
+		if _, err := os.Stat(dest); err == nil {
+			cached++
+			return nil
+		}
+
+		if err := render(inputs, dest); err != nil {
+			log.Printf("ogimage: %s: rendering: %v", path, err)
+			skipped++
+			return nil
+		}
+		generated++
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("ogimage: walking %s: %v", *contentDir, err)
+	}
+
+	if err := writeManifest(manifest); err != nil {
+		log.Fatalf("ogimage: writing manifest: %v", err)
+	}
+
+	fmt.Printf("ogimage: %d generated, %d already cached, %d skipped\n", generated, cached, skipped)
+}
+
+// contentRelPath returns path relative to contentDir, with its extension
+// dropped, matching the page path layouts/partials/og-image-meta.html looks up
+// the manifest by ({{ .File.Path }} without the extension).
+func contentRelPath(contentDir, path string) string {
+	rel, err := filepath.Rel(contentDir, path)
+	if err != nil {
+		rel = path
+	}
+	rel = filepath.ToSlash(rel)
+	return strings.TrimSuffix(rel, filepath.Ext(rel))
+}
+
+func coalesce(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// resolveCover turns a page-relative cover path (as used in a page bundle,
+// e.g. "cover.jpg" next to index.md) into a path on disk.
+func resolveCover(cover, pagePath string) string {
+	if cover == "" || strings.HasPrefix(cover, "http://") || strings.HasPrefix(cover, "https://") {
+		return cover
+	}
+	return filepath.Join(filepath.Dir(pagePath), cover)
+}
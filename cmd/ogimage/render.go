@@ -0,0 +1,154 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+	"github.com/edwvee/exiffix"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const defaultAccent = "#1e66f5"
+
+// render composites a 1200x630 OG image for inputs and writes it to dest as
+// a PNG: an accent-colored background, an optional cover photo (EXIF
+// auto-oriented and stripped, filling the right two-thirds of the canvas),
+// and the title/site/author overlaid as text on the left.
+func render(inputs pageInputs, dest string) error {
+	canvas := imaging.New(imageWidth, imageHeight, parseAccent(inputs.Accent))
+
+	textWidth := imageWidth
+	if inputs.Cover != "" {
+		cover, err := loadCover(inputs.Cover)
+		if err == nil {
+			const coverWidth = imageWidth * 2 / 3
+			fitted := imaging.Fill(cover, coverWidth, imageHeight, imaging.Center, imaging.Lanczos)
+			canvas = imaging.Paste(canvas, fitted, image.Pt(imageWidth-coverWidth, 0))
+			textWidth = imageWidth - coverWidth
+		}
+	}
+
+	drawText(canvas, inputs, textWidth)
+
+	return imaging.Save(canvas, dest)
+}
+
+// loadCover decodes a cover photo with exiffix, which both applies the
+// EXIF orientation tag (so portrait phone photos aren't rotated 90
+// degrees) and discards the EXIF block itself from the decoded image, so
+// no metadata from a user-provided photo ends up republished.
+func loadCover(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, _, err := exiffix.Decode(f)
+	return img, err
+}
+
+func drawText(canvas *image.NRGBA, inputs pageInputs, width int) {
+	const margin = 64
+	face := basicfont.Face7x13
+	white := image.NewUniform(color.White)
+
+	lines := wrapText(inputs.Title, face, width-2*margin)
+	y := imageHeight/3 - len(lines)*20/2
+	for _, line := range lines {
+		drawLine(canvas, face, white, line, margin, y)
+		y += 20
+	}
+
+	footer := inputs.Site
+	if inputs.Author != "" {
+		footer = inputs.Author + " · " + footer
+	}
+	drawLine(canvas, face, white, footer, margin, imageHeight-margin)
+}
+
+func drawLine(dst draw.Image, face font.Face, src image.Image, s string, x, y int) {
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  src,
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(s)
+}
+
+// wrapText greedily wraps s so each line fits within maxWidth pixels for
+// face, good enough for the short titles an OG image has room for.
+func wrapText(s string, face font.Face, maxWidth int) []string {
+	var lines []string
+	var line string
+	for _, word := range splitWords(s) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if measure(face, candidate) > maxWidth && line != "" {
+			lines = append(lines, line)
+			line = word
+			continue
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func splitWords(s string) []string {
+	var words []string
+	var cur []rune
+	for _, r := range s {
+		if r == ' ' {
+			if len(cur) > 0 {
+				words = append(words, string(cur))
+				cur = nil
+			}
+			continue
+		}
+		cur = append(cur, r)
+	}
+	if len(cur) > 0 {
+		words = append(words, string(cur))
+	}
+	return words
+}
+
+func measure(face font.Face, s string) int {
+	var w fixed.Int26_6
+	for _, r := range s {
+		adv, ok := face.GlyphAdvance(r)
+		if !ok {
+			continue
+		}
+		w += adv
+	}
+	return w.Ceil()
+}
+
+func parseAccent(accent string) color.Color {
+	if accent == "" {
+		accent = defaultAccent
+	}
+	if len(accent) == 7 && accent[0] == '#' {
+		if r, err := strconv.ParseUint(accent[1:3], 16, 8); err == nil {
+			if g, err := strconv.ParseUint(accent[3:5], 16, 8); err == nil {
+				if b, err := strconv.ParseUint(accent[5:7], 16, 8); err == nil {
+					return color.NRGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 255}
+				}
+			}
+		}
+	}
+	return color.NRGBA{R: 30, G: 102, B: 245, A: 255}
+}
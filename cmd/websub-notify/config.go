@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/BurntSushi/toml"
+)
+
+// feedConfig is one entry in params.websub.feeds: a feed's path relative to
+// public/, its public URL, and an optional hub overriding params.websub.hub.
+type feedConfig struct {
+	Path string `toml:"path"`
+	URL  string `toml:"url"`
+	Hub  string `toml:"hub"`
+}
+
+// websubConfig mirrors params.websub in hugo.toml.
+type websubConfig struct {
+	DefaultHub string       `toml:"hub"`
+	Feeds      []feedConfig `toml:"feeds"`
+}
+
+type siteConfig struct {
+	Params struct {
+		Websub websubConfig `toml:"websub"`
+	} `toml:"params"`
+}
+
+func loadConfig(path string) (websubConfig, error) {
+	var site siteConfig
+	if _, err := toml.DecodeFile(path, &site); err != nil {
+		return websubConfig{}, err
+	}
+	return site.Params.Websub, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hugo.toml")
+	content := `
+[params.websub]
+  hub = "https://pubsubhubbub.appspot.com/"
+  [[params.websub.feeds]]
+    path = "index.xml"
+    url = "https://blog.cocl2.com/index.xml"
+  [[params.websub.feeds]]
+    path = "posts/index.xml"
+    url = "https://blog.cocl2.com/posts/index.xml"
+    hub = "https://other-hub.example/"
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	got, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+
+	if got.DefaultHub != "https://pubsubhubbub.appspot.com/" {
+		t.Errorf("DefaultHub = %q", got.DefaultHub)
+	}
+	if len(got.Feeds) != 2 {
+		t.Fatalf("Feeds = %+v, want 2 entries", got.Feeds)
+	}
+	if got.Feeds[0].Path != "index.xml" || got.Feeds[0].Hub != "" {
+		t.Errorf("Feeds[0] = %+v, want no per-feed hub override", got.Feeds[0])
+	}
+	if got.Feeds[1].Hub != "https://other-hub.example/" {
+		t.Errorf("Feeds[1].Hub = %q, want the per-feed override", got.Feeds[1].Hub)
+	}
+}
+
+func TestLoadConfigNoWebsubSection(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hugo.toml")
+	if err := os.WriteFile(path, []byte(`title = "blog-cocl2"`), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	got, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if got.DefaultHub != "" || len(got.Feeds) != 0 {
+		t.Errorf("loadConfig = %+v, want zero value", got)
+	}
+}
@@ -0,0 +1,77 @@
+// Command websub-notify pings each feed's WebSub hub (as configured under
+// params.websub in hugo.toml) whenever the rendered feed's content has
+// changed since the last run. It's meant to be run after `hugo` has
+// written public/, e.g. as a post-build step in CI:
+//
+//	hugo --gc && websub-notify
+//
+// Last-notified content hashes are persisted to
+// resources/_gen/websub.json so repeated runs (or repeated CI builds of an
+// unchanged feed) don't re-ping the hub.
+package main
+
+import (
+	"flag"
+	"log"
+)
+
+func main() {
+	configPath := flag.String("config", "hugo.toml", "path to the Hugo site config")
+	publicDir := flag.String("public-dir", "public", "path to Hugo's rendered output")
+	manifestPath := flag.String("manifest", "resources/_gen/websub.json", "path to the last-notified hash manifest")
+	dryRun := flag.Bool("dry-run", false, "log what would be notified without POSTing to any hub")
+	flag.Parse()
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("websub-notify: loading %s: %v", *configPath, err)
+	}
+	if len(cfg.Feeds) == 0 {
+		log.Printf("websub-notify: no feeds configured under params.websub.feeds in %s, nothing to do", *configPath)
+		return
+	}
+
+	manifest, err := loadManifest(*manifestPath)
+	if err != nil {
+		log.Fatalf("websub-notify: loading %s: %v", *manifestPath, err)
+	}
+
+	notifier := &notifier{dryRun: *dryRun}
+	changed := false
+	for _, feed := range cfg.Feeds {
+		hub := feed.Hub
+		if hub == "" {
+			hub = cfg.DefaultHub
+		}
+		if hub == "" {
+			log.Printf("websub-notify: %s: no hub configured, skipping", feed.Path)
+			continue
+		}
+
+		hash, err := hashFile(*publicDir, feed.Path)
+		if err != nil {
+			log.Printf("websub-notify: %s: %v", feed.Path, err)
+			continue
+		}
+
+		if manifest.Hashes[feed.Path] == hash {
+			log.Printf("websub-notify: %s: unchanged, skipping", feed.Path)
+			continue
+		}
+
+		if err := notifier.notify(hub, feed.URL); err != nil {
+			log.Printf("websub-notify: %s: notifying %s: %v", feed.Path, hub, err)
+			continue
+		}
+
+		log.Printf("websub-notify: %s: notified %s", feed.Path, hub)
+		manifest.Hashes[feed.Path] = hash
+		changed = true
+	}
+
+	if changed && !*dryRun {
+		if err := manifest.writeTo(*manifestPath); err != nil {
+			log.Fatalf("websub-notify: writing %s: %v", *manifestPath, err)
+		}
+	}
+}
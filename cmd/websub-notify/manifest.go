@@ -0,0 +1,53 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// manifest is the persisted resources/_gen/websub.json: the content hash we
+// last successfully notified the hub about, per feed path.
+type manifest struct {
+	Hashes map[string]string `json:"hashes"`
+}
+
+func loadManifest(path string) (*manifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &manifest{Hashes: map[string]string{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if m.Hashes == nil {
+		m.Hashes = map[string]string{}
+	}
+	return &m, nil
+}
+
+func (m *manifest) writeTo(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func hashFile(publicDir, feedPath string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(publicDir, feedPath))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
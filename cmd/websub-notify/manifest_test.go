@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadManifestMissingFile(t *testing.T) {
+	m, err := loadManifest(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("loadManifest: %v", err)
+	}
+	if m.Hashes == nil || len(m.Hashes) != 0 {
+		t.Errorf("Hashes = %v, want empty non-nil map", m.Hashes)
+	}
+}
+
+func TestManifestWriteAndLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "websub.json")
+	m := &manifest{Hashes: map[string]string{"index.xml": "abc123"}}
+	if err := m.writeTo(path); err != nil {
+		t.Fatalf("writeTo: %v", err)
+	}
+
+	loaded, err := loadManifest(path)
+	if err != nil {
+		t.Fatalf("loadManifest: %v", err)
+	}
+	if loaded.Hashes["index.xml"] != "abc123" {
+		t.Errorf("Hashes = %v, want index.xml -> abc123", loaded.Hashes)
+	}
+}
+
+func TestHashFileChangesWithContent(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.xml"), []byte("v1"), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+	h1, err := hashFile(dir, "index.xml")
+	if err != nil {
+		t.Fatalf("hashFile: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "index.xml"), []byte("v2"), 0o644); err != nil {
+		t.Fatalf("rewriting fixture: %v", err)
+	}
+	h2, err := hashFile(dir, "index.xml")
+	if err != nil {
+		t.Fatalf("hashFile: %v", err)
+	}
+
+	if h1 == h2 {
+		t.Error("hashFile should change when the file's content changes")
+	}
+}
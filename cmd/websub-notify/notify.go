@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// notifier POSTs the WebSub publish request (hub.mode=publish,
+// hub.url=<feed>) that tells the hub to re-fetch a changed feed.
+type notifier struct {
+	client http.Client
+	dryRun bool
+}
+
+func (n *notifier) notify(hub, feedURL string) error {
+	if n.dryRun {
+		log.Printf("websub-notify: [dry-run] would POST to %s for %s", hub, feedURL)
+		return nil
+	}
+
+	if n.client.Timeout == 0 {
+		n.client.Timeout = 15 * time.Second
+	}
+
+	form := url.Values{
+		"hub.mode": {"publish"},
+		"hub.url":  {feedURL},
+	}
+	resp, err := n.client.PostForm(hub, form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("hub returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Package buildcache is a memory-bounded, dependency-aware cache for the
+// expensive, deterministic build steps this site runs before `hugo`
+// itself: image resizing (hugomods/images), OG-image generation
+// (cmd/ogimage), and minified CSS bundles (cmd/gen-chromastyles).
+//
+// Entries are keyed by a content hash plus an explicit dependency set
+// (source files, params, module versions); anything not named as a
+// dependency is assumed not to affect the output and won't bust the
+// cache. Eviction uses a Segmented LRU so a single large scan of cold
+// entries (e.g. a full rebuild) can't flush out hot ones.
+package buildcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// Key identifies one cache entry: a name for the step producing it (e.g.
+// "og-image", "chroma-css") plus the set of dependencies whose combined
+// hash, along with the name, determines the cache key.
+type Key struct {
+	Step string
+	Deps []string // source file contents/paths, param values, module versions, ...
+}
+
+func (k Key) hash() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", k.Step)
+	for _, dep := range k.Deps {
+		fmt.Fprintf(h, "%s\x00", dep)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Cache is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	policy  *slru
+	maxMB   int
+	metrics Metrics
+}
+
+// Option configures a Cache.
+type Option func(*Cache)
+
+// WithMaxMB sets the memory ceiling in megabytes. If unset or zero, New
+// defaults to 25% of the host's total physical memory, matching
+// params.cache.maxMB's documented default.
+func WithMaxMB(mb int) Option {
+	return func(c *Cache) { c.maxMB = mb }
+}
+
+// New creates an empty Cache enforcing the configured (or default) memory
+// ceiling. It does not read a prior run's manifest; LoadManifest does that
+// separately.
+func New(opts ...Option) *Cache {
+	c := &Cache{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.maxMB == 0 {
+		c.maxMB = totalSystemMemoryMB() / 4
+		if c.maxMB == 0 {
+			c.maxMB = defaultMaxMBFallback
+		}
+	}
+	c.policy = newSLRU(int64(c.maxMB) * 1024 * 1024)
+	return c
+}
+
+// Get returns the cached bytes for key, or ok=false on a miss.
+func (c *Cache) Get(key Key) (data []byte, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, ok = c.policy.get(key.hash())
+	if ok {
+		c.metrics.Hits++
+	} else {
+		c.metrics.Misses++
+	}
+	return data, ok
+}
+
+// Put stores data under key, evicting cold entries if the memory ceiling
+// would otherwise be exceeded.
+func (c *Cache) Put(key Key, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	evicted := c.policy.put(key.hash(), data)
+	c.metrics.Evictions += evicted
+	c.metrics.Bytes = c.policy.bytes
+}
+
+// Metrics returns a snapshot of the cache's hit/miss/eviction/byte
+// counters, suitable for writing out as JSON after a build for CI
+// regression tracking (see WriteMetrics).
+func (c *Cache) Metrics() Metrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.metrics
+}
@@ -0,0 +1,51 @@
+package buildcache
+
+import "testing"
+
+func TestKeyHashStable(t *testing.T) {
+	a := Key{Step: "og-image", Deps: []string{"dep1", "dep2"}}
+	b := Key{Step: "og-image", Deps: []string{"dep1", "dep2"}}
+	if a.hash() != b.hash() {
+		t.Fatal("identical keys should hash identically")
+	}
+}
+
+func TestKeyHashDiffersOnStepOrDeps(t *testing.T) {
+	base := Key{Step: "og-image", Deps: []string{"dep1"}}
+	cases := []Key{
+		{Step: "syntax-theme", Deps: []string{"dep1"}},
+		{Step: "og-image", Deps: []string{"dep2"}},
+		{Step: "og-image", Deps: []string{"dep1", "dep2"}},
+	}
+	for _, c := range cases {
+		if base.hash() == c.hash() {
+			t.Errorf("Key{%q, %v} hashed the same as Key{%q, %v}", base.Step, base.Deps, c.Step, c.Deps)
+		}
+	}
+}
+
+func TestCacheGetPutRoundTrip(t *testing.T) {
+	c := New(WithMaxMB(1))
+	key := Key{Step: "og-image", Deps: []string{"v1"}}
+
+	if _, ok := c.Get(key); ok {
+		t.Fatal("expected miss before any Put")
+	}
+	c.Put(key, []byte("payload"))
+	data, ok := c.Get(key)
+	if !ok || string(data) != "payload" {
+		t.Fatalf("Get after Put = %q, %v, want \"payload\", true", data, ok)
+	}
+
+	m := c.Metrics()
+	if m.Hits != 1 || m.Misses != 1 {
+		t.Errorf("Metrics = %+v, want 1 hit and 1 miss", m)
+	}
+}
+
+func TestNewDefaultsMaxMBFromSystemMemory(t *testing.T) {
+	c := New()
+	if c.maxMB <= 0 {
+		t.Fatalf("maxMB = %d, want a positive default", c.maxMB)
+	}
+}
@@ -0,0 +1,48 @@
+package buildcache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// ManifestEntry is one row of the on-disk manifest: enough to locate and
+// validate a cached artifact without re-deriving it.
+type ManifestEntry struct {
+	Hash string `json:"hash"`
+	Path string `json:"path"` // artifact location as recorded by the caller (cmd/build stores the step's output directory)
+	Size int64  `json:"size"`
+}
+
+// SaveManifest writes entries, a small index of cached artifacts, as JSON to
+// path (cmd/build uses resources/_gen/cache-manifest.json) so a cold start (e.g. a fresh CI
+// checkout) can reuse on-disk artifacts instead of recomputing everything
+// that was already cached by a previous run.
+func SaveManifest(path string, entries map[string]ManifestEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadManifest reads a manifest previously written by SaveManifest. A
+// missing file is not an error: it just means there's nothing to warm the
+// cache with yet.
+func LoadManifest(path string) (map[string]ManifestEntry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]ManifestEntry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entries := map[string]ManifestEntry{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,24 @@
+package buildcache
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Metrics tallies cache activity over a Cache's lifetime, written out
+// after each build so CI can track cache effectiveness over time.
+type Metrics struct {
+	Hits      int   `json:"hits"`
+	Misses    int   `json:"misses"`
+	Evictions int   `json:"evictions"`
+	Bytes     int64 `json:"bytes"`
+}
+
+// WriteMetrics writes m as JSON to path.
+func WriteMetrics(path string, m Metrics) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,97 @@
+package buildcache
+
+import "container/list"
+
+// slru is a Segmented LRU: entries enter the probationary segment and are
+// promoted to protected on a second access. Eviction always takes from
+// probationary first, so a scan of many cold, once-touched entries can't
+// displace protected (hot) entries — the property this cache needs when a
+// full `hugo --gc` walks every asset in a build that only changed a few of
+// them.
+type slru struct {
+	maxBytes int64
+	bytes    int64
+
+	// probationary holds entries, most-recently-used at the front, that
+	// have only been accessed once since entering the cache.
+	probationary *list.List
+	// protected holds entries that have been accessed at least twice.
+	protected *list.List
+
+	index map[string]*list.Element
+}
+
+type slruEntry struct {
+	key         string
+	data        []byte
+	inProtected bool
+}
+
+func newSLRU(maxBytes int64) *slru {
+	return &slru{
+		maxBytes:     maxBytes,
+		probationary: list.New(),
+		protected:    list.New(),
+		index:        make(map[string]*list.Element),
+	}
+}
+
+func (s *slru) get(key string) ([]byte, bool) {
+	el, ok := s.index[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*slruEntry)
+
+	if e.inProtected {
+		s.protected.MoveToFront(el)
+	} else {
+		s.probationary.Remove(el)
+		e.inProtected = true
+		s.index[key] = s.protected.PushFront(e)
+	}
+	return e.data, true
+}
+
+// put inserts or overwrites key and evicts cold entries until the cache
+// fits within maxBytes. It returns how many entries were evicted.
+func (s *slru) put(key string, data []byte) int {
+	if el, ok := s.index[key]; ok {
+		e := el.Value.(*slruEntry)
+		s.bytes -= int64(len(e.data))
+		e.data = data
+		s.bytes += int64(len(data))
+	} else {
+		e := &slruEntry{key: key, data: data}
+		s.index[key] = s.probationary.PushFront(e)
+		s.bytes += int64(len(data))
+	}
+
+	var evicted int
+	for s.bytes > s.maxBytes {
+		if !s.evictOne() {
+			break
+		}
+		evicted++
+	}
+	return evicted
+}
+
+// evictOne removes the least-recently-used probationary entry, falling
+// back to the least-recently-used protected entry if probationary is
+// empty. It reports whether anything was evicted.
+func (s *slru) evictOne() bool {
+	seg := s.probationary
+	if seg.Len() == 0 {
+		seg = s.protected
+	}
+	back := seg.Back()
+	if back == nil {
+		return false
+	}
+	e := back.Value.(*slruEntry)
+	seg.Remove(back)
+	delete(s.index, e.key)
+	s.bytes -= int64(len(e.data))
+	return true
+}
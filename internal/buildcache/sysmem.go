@@ -0,0 +1,41 @@
+package buildcache
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// defaultMaxMBFallback is used when the host's total memory can't be
+// determined (non-Linux, or /proc/meminfo unreadable in a sandboxed
+// environment), so the cache still has a sane ceiling rather than none.
+const defaultMaxMBFallback = 512
+
+// totalSystemMemoryMB returns the host's total physical memory in
+// megabytes, read from /proc/meminfo's MemTotal line. Go's standard
+// library has no portable "total system memory" query; runtime.MemStats
+// only reports this process's own usage, which is the wrong number for a
+// memory ceiling meant to bound how much of the *machine's* memory the
+// cache may use.
+func totalSystemMemoryMB() int {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0
+		}
+		return int(kb / 1024)
+	}
+	return 0
+}
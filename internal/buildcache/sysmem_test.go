@@ -0,0 +1,13 @@
+package buildcache
+
+import "testing"
+
+func TestTotalSystemMemoryMB(t *testing.T) {
+	mb := totalSystemMemoryMB()
+	if mb <= 0 {
+		t.Skip("/proc/meminfo not available in this environment")
+	}
+	if mb < 64 {
+		t.Errorf("totalSystemMemoryMB() = %d, implausibly low", mb)
+	}
+}